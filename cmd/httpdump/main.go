@@ -0,0 +1,79 @@
+// Command httpdump runs the HTTP request & response test service.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gebi/httpdump/internal/handlers/auth"
+	"github.com/gebi/httpdump/internal/handlers/body"
+	"github.com/gebi/httpdump/internal/handlers/bytes"
+	"github.com/gebi/httpdump/internal/handlers/cookies"
+	"github.com/gebi/httpdump/internal/handlers/delay"
+	"github.com/gebi/httpdump/internal/handlers/encoding"
+	"github.com/gebi/httpdump/internal/handlers/get"
+	"github.com/gebi/httpdump/internal/handlers/headers"
+	"github.com/gebi/httpdump/internal/handlers/index"
+	"github.com/gebi/httpdump/internal/handlers/ip"
+	"github.com/gebi/httpdump/internal/handlers/proxy"
+	"github.com/gebi/httpdump/internal/handlers/redirect"
+	"github.com/gebi/httpdump/internal/handlers/responseheaders"
+	"github.com/gebi/httpdump/internal/handlers/status"
+	"github.com/gebi/httpdump/internal/handlers/stream"
+	"github.com/gebi/httpdump/internal/handlers/useragent"
+	"github.com/gebi/httpdump/internal/middleware"
+	"github.com/gebi/httpdump/internal/render"
+)
+
+func main() {
+	listen := flag.String("listen", "127.0.0.1:8090", "The host and port to listen on.")
+	pretty := flag.Bool("pretty", false, "Pretty print json output")
+	debug := flag.Bool("debug", false, "Log requests to stdout")
+	proxyAllow := flag.String("proxy-allow", "", "Comma-separated host globs the /proxy endpoint may reach (empty allows any public host)")
+	proxyTimeout := flag.Duration("proxy-timeout", 10*time.Second, "Timeout for upstream requests made by the /proxy endpoint")
+	flag.Parse()
+
+	r := render.JSON{Pretty: *pretty}
+	mw := middleware.Config{Debug: *debug}
+	proxyCfg := proxy.Config{Timeout: *proxyTimeout}
+	if *proxyAllow != "" {
+		proxyCfg.Allow = strings.Split(*proxyAllow, ",")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", index.New())
+	mux.Handle("/headers", headers.New(r))
+	mux.Handle("/status/", status.New())
+	mux.Handle("/ip", ip.New(r))
+	mux.Handle("/get", get.New(r))
+	mux.Handle("/post", body.New(r, "POST"))
+	mux.Handle("/put", body.New(r, "PUT"))
+	mux.Handle("/patch", body.New(r, "PATCH"))
+	mux.Handle("/delete", body.New(r, "DELETE"))
+	mux.Handle("/anything", body.New(r, ""))
+	mux.Handle("/anything/", body.New(r, ""))
+	mux.Handle("/gzip", encoding.New(r, "gzip"))
+	mux.Handle("/deflate", encoding.New(r, "deflate"))
+	mux.Handle("/brotli", encoding.New(r, "br"))
+	mux.Handle("/response-headers", responseheaders.New(r))
+	mux.Handle("/user-agent", useragent.New(r))
+	mux.Handle("/bytes/", bytes.New())
+	mux.Handle("/stream/", stream.New(r))
+	mux.Handle("/redirect-to", redirect.New())
+	mux.Handle("/proxy", proxy.New(proxyCfg))
+	mux.Handle("/proxy/", proxy.New(proxyCfg))
+	mux.Handle("/cookies", cookies.List(r))
+	mux.Handle("/cookies/set", cookies.Set())
+	mux.Handle("/cookies/set/", cookies.Set())
+	mux.Handle("/cookies/delete", cookies.Delete())
+	mux.Handle("/basic-auth/", auth.Basic(r, false))
+	mux.Handle("/hidden-basic-auth/", auth.Basic(r, true))
+	mux.Handle("/bearer", auth.Bearer(r))
+	mux.Handle("/digest-auth/", auth.DigestAuth(r))
+	mux.Handle("/delay/", delay.New(r))
+
+	log.Fatal(http.ListenAndServe(*listen, mw.Default(mux)))
+}
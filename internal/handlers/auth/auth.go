@@ -0,0 +1,39 @@
+// Package auth implements httpdump's authentication challenge endpoints.
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gebi/httpdump/internal/render"
+)
+
+// AuthedResponse is returned once a challenge has been satisfied.
+type AuthedResponse struct {
+	Authenticated bool   `json:"authenticated"`
+	User          string `json:"user"`
+}
+
+// Basic returns the handler for /basic-auth/:user/:passwd. When hidden is
+// true, a failed challenge returns 404 instead of a WWW-Authenticate
+// challenge, matching /hidden-basic-auth/:user/:passwd.
+func Basic(r render.JSON, hidden bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		params := strings.Split(req.URL.Path, "/")
+		if len(params) < 4 {
+			http.NotFound(w, req)
+			return
+		}
+		u, p, ok := req.BasicAuth()
+		if !ok || u != params[2] || p != params[3] {
+			if !hidden {
+				w.Header().Set("WWW-Authenticate", "Basic realm=\"httpdump\"")
+				w.WriteHeader(http.StatusUnauthorized)
+			} else {
+				http.NotFound(w, req)
+			}
+			return
+		}
+		r.Write(w, AuthedResponse{true, u}, http.StatusOK)
+	})
+}
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gebi/httpdump/internal/render"
+)
+
+type bearerResponse struct {
+	Authenticated bool   `json:"authenticated"`
+	Token         string `json:"token"`
+}
+
+// Bearer returns the handler for /bearer. It requires an
+// "Authorization: Bearer <token>" header and echoes the token back.
+func Bearer(r render.JSON) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		const prefix = "Bearer "
+		h := req.Header.Get("Authorization")
+		if !strings.HasPrefix(h, prefix) || h == prefix {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		r.Write(w, bearerResponse{true, strings.TrimPrefix(h, prefix)}, http.StatusOK)
+	})
+}
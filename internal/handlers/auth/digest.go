@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gebi/httpdump/internal/render"
+)
+
+// digestNonces bounds how long a nonce issued by a 401 challenge may be
+// used to complete the handshake.
+var digestNonces = newNonceStore(5 * time.Minute)
+
+func hash(algorithm string) func(string) string {
+	if strings.EqualFold(algorithm, "SHA-256") {
+		return func(s string) string {
+			sum := sha256.Sum256([]byte(s))
+			return hex.EncodeToString(sum[:])
+		}
+	}
+	return func(s string) string {
+		sum := md5.Sum([]byte(s))
+		return hex.EncodeToString(sum[:])
+	}
+}
+
+func parseDigestHeader(s string) map[string]string {
+	out := make(map[string]string)
+	for _, part := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		out[kv[0]] = strings.Trim(kv[1], `"`)
+	}
+	return out
+}
+
+// DigestAuth returns the handler for
+// /digest-auth/:qop/:user/:passwd[/:algorithm], implementing RFC 7616
+// digest authentication. algorithm defaults to MD5 and may also be
+// SHA-256.
+func DigestAuth(r render.JSON) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		params := strings.Split(req.URL.Path, "/")
+		if len(params) < 5 || params[2] == "" || params[3] == "" || params[4] == "" {
+			http.NotFound(w, req)
+			return
+		}
+		qop, user, passwd := params[2], params[3], params[4]
+		algorithm := "MD5"
+		if len(params) > 5 && params[5] != "" {
+			algorithm = strings.ToUpper(params[5])
+		}
+
+		challenge := func() {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+				`Digest realm="httpdump", qop=%q, nonce=%q, opaque=%q, algorithm=%s`,
+				qop, digestNonces.issue(), randomHex(16), algorithm))
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+
+		auth := req.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Digest ") {
+			challenge()
+			return
+		}
+		resp := parseDigestHeader(strings.TrimPrefix(auth, "Digest "))
+		if resp["username"] != user || !digestNonces.valid(resp["nonce"]) {
+			challenge()
+			return
+		}
+
+		h := hash(algorithm)
+		ha1 := h(strings.Join([]string{user, "httpdump", passwd}, ":"))
+		ha2 := h(strings.Join([]string{req.Method, resp["uri"]}, ":"))
+		want := h(strings.Join([]string{ha1, resp["nonce"], resp["nc"], resp["cnonce"], resp["qop"], ha2}, ":"))
+		if subtle.ConstantTimeCompare([]byte(want), []byte(resp["response"])) != 1 {
+			challenge()
+			return
+		}
+		r.Write(w, AuthedResponse{true, user}, http.StatusOK)
+	})
+}
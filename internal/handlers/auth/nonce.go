@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// nonceStore tracks recently issued digest-auth nonces so a replayed
+// Authorization header without a matching challenge is rejected.
+type nonceStore struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	nonces map[string]time.Time
+}
+
+func newNonceStore(ttl time.Duration) *nonceStore {
+	return &nonceStore{ttl: ttl, nonces: make(map[string]time.Time)}
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (s *nonceStore) issue() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for n, exp := range s.nonces {
+		if now.After(exp) {
+			delete(s.nonces, n)
+		}
+	}
+	nonce := randomHex(16)
+	s.nonces[nonce] = now.Add(s.ttl)
+	return nonce
+}
+
+func (s *nonceStore) valid(nonce string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exp, ok := s.nonces[nonce]
+	return ok && time.Now().Before(exp)
+}
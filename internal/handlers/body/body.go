@@ -0,0 +1,28 @@
+// Package body handles the content-type-aware body-echoing endpoints:
+// /post, /put, /patch, /delete and /anything.
+package body
+
+import (
+	"net/http"
+
+	"github.com/gebi/httpdump/internal/httpreq"
+	"github.com/gebi/httpdump/internal/render"
+)
+
+// New returns a handler that parses and echoes the request body, matching
+// httpbin's /post, /put, /patch, /delete and /anything semantics. An empty
+// method accepts any request method.
+func New(r render.JSON, method string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if method != "" && req.Method != method {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		ret := httpreq.New(req)
+		ret.Method = req.Method
+		if !ret.ParseBody(w, req) {
+			return
+		}
+		r.Write(w, ret, http.StatusOK)
+	})
+}
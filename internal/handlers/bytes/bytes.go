@@ -0,0 +1,29 @@
+// Package bytes generates random binary bodies.
+package bytes
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/gebi/httpdump/internal/httpreq"
+)
+
+// New returns the handler for /bytes/:n.
+func New() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.Atoi(path.Base(r.URL.Path))
+		if err != nil || n < 0 || n > httpreq.MaxBytes {
+			http.Error(w, fmt.Sprintf("number of bytes must be in range: 0 - %d", httpreq.MaxBytes), http.StatusBadRequest)
+			return
+		}
+		b := make([]byte, n)
+		if _, err := rand.Read(b); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(b)
+	})
+}
@@ -0,0 +1,116 @@
+// Package cookies implements httpdump's cookie inspection and manipulation
+// endpoints.
+package cookies
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gebi/httpdump/internal/render"
+)
+
+var reserved = map[string]bool{"secure": true, "httponly": true, "samesite": true}
+
+// attrs holds the Set-Cookie attributes callers can opt into via query
+// parameters, so the full attribute matrix net/http/cookiejar clients need
+// to be tested against can be exercised.
+type attrs struct {
+	secure   bool
+	httpOnly bool
+	sameSite http.SameSite
+}
+
+func parseAttrs(q url.Values) attrs {
+	a := attrs{sameSite: http.SameSiteDefaultMode}
+	if q.Get("secure") != "" {
+		a.secure = true
+	}
+	if q.Get("httponly") != "" {
+		a.httpOnly = true
+	}
+	switch strings.ToLower(q.Get("samesite")) {
+	case "lax":
+		a.sameSite = http.SameSiteLaxMode
+	case "strict":
+		a.sameSite = http.SameSiteStrictMode
+	case "none":
+		a.sameSite = http.SameSiteNoneMode
+	}
+	return a
+}
+
+func setCookie(w http.ResponseWriter, a attrs, name, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   a.secure,
+		HttpOnly: a.httpOnly,
+		SameSite: a.sameSite,
+	})
+}
+
+// List returns the handler for /cookies.
+func List(r render.JSON) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		cookies := make(map[string]string)
+		for _, c := range req.Cookies() {
+			cookies[c.Name] = c.Value
+		}
+		r.Write(w, struct {
+			Cookies map[string]string `json:"cookies"`
+		}{cookies}, http.StatusOK)
+	})
+}
+
+const setPrefix = "/cookies/set/"
+
+func pathPair(p string) (name, value string, ok bool) {
+	if !strings.HasPrefix(p, setPrefix) {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimPrefix(p, setPrefix), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// Set returns the handler for /cookies/set?name=value&... and for the
+// single-cookie path form /cookies/set/:name/:value. It redirects to
+// /cookies once the cookies have been set.
+func Set() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		a := parseAttrs(req.URL.Query())
+		if name, value, ok := pathPair(req.URL.Path); ok {
+			setCookie(w, a, name, value, 0)
+		} else {
+			for name, values := range req.URL.Query() {
+				if reserved[strings.ToLower(name)] || len(values) == 0 {
+					continue
+				}
+				setCookie(w, a, name, values[0], 0)
+			}
+		}
+		http.Redirect(w, req, "/cookies", http.StatusFound)
+	})
+}
+
+// Delete returns the handler for /cookies/delete?name=.... Each named
+// cookie is expired with Max-Age=0 and the caller is redirected to
+// /cookies.
+func Delete() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		for name := range req.URL.Query() {
+			http.SetCookie(w, &http.Cookie{
+				Name:   name,
+				Value:  "",
+				Path:   "/",
+				MaxAge: -1,
+			})
+		}
+		http.Redirect(w, req, "/cookies", http.StatusFound)
+	})
+}
@@ -0,0 +1,35 @@
+// Package delay holds responses for a requested number of seconds.
+package delay
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/gebi/httpdump/internal/httpreq"
+	"github.com/gebi/httpdump/internal/render"
+)
+
+func min(a, b int) int {
+	if a <= b {
+		return a
+	}
+	return b
+}
+
+// New returns the handler for /delay/:n.
+func New(r render.JSON) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		n, err := strconv.Atoi(path.Base(req.URL.Path))
+		if err != nil {
+			http.Error(w, "you must specify a delay", http.StatusBadRequest)
+			return
+		}
+		n = min(n, 10)
+		if n > 0 {
+			<-time.After(time.Second * time.Duration(n))
+		}
+		r.Write(w, httpreq.New(req), http.StatusOK)
+	})
+}
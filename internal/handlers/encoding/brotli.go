@@ -0,0 +1,13 @@
+//go:build brotli
+
+package encoding
+
+import (
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+func newBrotliWriter(w io.Writer) (writer, error) {
+	return brotli.NewWriter(w), nil
+}
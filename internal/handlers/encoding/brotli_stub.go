@@ -0,0 +1,16 @@
+//go:build !brotli
+
+package encoding
+
+import (
+	"errors"
+	"io"
+)
+
+// newBrotliWriter is a stub used when httpdump is built without the
+// brotli tag, so the default build doesn't need the
+// github.com/andybalholm/brotli dependency. /brotli falls back to
+// identity responses in that case.
+func newBrotliWriter(w io.Writer) (writer, error) {
+	return nil, errors.New("brotli support not compiled in; rebuild with -tags brotli")
+}
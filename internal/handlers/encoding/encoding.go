@@ -0,0 +1,92 @@
+// Package encoding implements httpdump's content-encoding family:
+// /gzip, /deflate, /brotli and the plain identity response. Each shares a
+// writer abstraction and negotiates against Accept-Encoding rather than
+// always compressing, matching how real clients probe content-encoding
+// support.
+package encoding
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gebi/httpdump/internal/httpreq"
+	"github.com/gebi/httpdump/internal/render"
+)
+
+var errUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// writer is the minimal interface every content-coding writer needs:
+// something to stream JSON through before flushing on Close.
+type writer interface {
+	io.WriteCloser
+}
+
+func newWriter(enc string, w io.Writer) (writer, error) {
+	switch enc {
+	case "gzip":
+		return gzip.NewWriter(w), nil
+	case "deflate":
+		return flate.NewWriter(w, flate.DefaultCompression)
+	case "br":
+		return newBrotliWriter(w)
+	default:
+		return nil, errUnsupportedEncoding
+	}
+}
+
+// accepts reports whether header (an Accept-Encoding value) lists enc, or
+// enc is the identity encoding which every client accepts.
+func accepts(header, enc string) bool {
+	if enc == "" {
+		return true
+	}
+	for _, part := range strings.Split(header, ",") {
+		token := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if token == "*" || strings.EqualFold(token, enc) {
+			return true
+		}
+	}
+	return false
+}
+
+// New returns the handler for a given content-coding: "gzip", "deflate",
+// "br", or "" for the uncompressed identity response. The encoding is only
+// applied when the request's Accept-Encoding header advertises support for
+// it; otherwise the response falls back to identity.
+func New(r render.JSON, enc string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ret := httpreq.New(req)
+
+		negotiated := enc
+		if negotiated != "" && !accepts(req.Header.Get("Accept-Encoding"), negotiated) {
+			negotiated = ""
+		}
+
+		var cw writer
+		if negotiated != "" {
+			var err error
+			if cw, err = newWriter(negotiated, w); err != nil {
+				negotiated = ""
+			}
+		}
+		ret.Encoding = negotiated
+		ret.Gzipped = negotiated == "gzip"
+
+		r.Header(w)
+		if negotiated != "" {
+			w.Header().Set("Content-Encoding", negotiated)
+		}
+		w.WriteHeader(http.StatusOK)
+
+		if cw == nil {
+			r.Encode(w, ret)
+			return
+		}
+		defer cw.Close()
+		r.Encode(cw, ret)
+	})
+}
@@ -0,0 +1,20 @@
+// Package get handles plain GET echo requests.
+package get
+
+import (
+	"net/http"
+
+	"github.com/gebi/httpdump/internal/httpreq"
+	"github.com/gebi/httpdump/internal/render"
+)
+
+// New returns the handler for /get.
+func New(r render.JSON) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "GET" {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		r.Write(w, httpreq.New(req), http.StatusOK)
+	})
+}
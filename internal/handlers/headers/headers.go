@@ -0,0 +1,16 @@
+// Package headers echoes the caller's request headers.
+package headers
+
+import (
+	"net/http"
+
+	"github.com/gebi/httpdump/internal/render"
+)
+
+// New returns the handler for /headers.
+func New(r render.JSON) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		req.Header.Add("Host", req.Host)
+		r.Write(w, req.Header, http.StatusOK)
+	})
+}
@@ -0,0 +1,47 @@
+// Package index serves httpdump's endpoint listing.
+package index
+
+import "net/http"
+
+const page = `<html>
+<body id='manpage'>
+<h1>httpdump(1): HTTP Request &amp; Response Service</h1>
+
+<h2 id="ENDPOINTS">ENDPOINTS</h2>
+
+<ul>
+<li><a href="/" data-bare-link="true"><code>/</code></a> This page.</li>
+<li><a href="./ip" data-bare-link="true"><code>/ip</code></a> Returns Origin IP.</li>
+<li><a href="./user-agent" data-bare-link="true"><code>/user-agent</code></a> Returns user-agent.</li>
+<li><a href="./headers" data-bare-link="true"><code>/headers</code></a> Returns header dict.</li>
+<li><a href="./get" data-bare-link="true"><code>/get</code></a> Returns GET data.</li>
+<li><code>/post</code>, <code>/put</code>, <code>/patch</code>, <code>/delete</code> Return the parsed request body for the matching method.</li>
+<li><code>/anything</code>, <code>/anything/*</code> Return the parsed request body for any method.</li>
+<li><a href="./gzip" data-bare-link="true"><code>/gzip</code></a> Returns gzip-encoded data if Accept-Encoding allows it, identity otherwise.</li>
+<li><a href="./deflate" data-bare-link="true"><code>/deflate</code></a> Returns deflate-encoded data if Accept-Encoding allows it, identity otherwise.</li>
+<li><a href="./brotli" data-bare-link="true"><code>/brotli</code></a> Returns brotli-encoded data if Accept-Encoding allows it and httpdump was built with the brotli tag, identity otherwise.</li>
+<li><code>/response-headers?Key=Value</code> Sets the given headers and echoes the resulting header set.</li>
+<li><a href="./status/418"><code>/status/:code</code></a> Returns given HTTP Status code.</li>
+<li><a href="./stream/20"><code>/stream/:n</code></a> Streams <em>n</em>–100 lines.</li>
+<li><a href="./bytes/1024"><code>/bytes/:n</code></a> Generates <em>n</em> random bytes of binary data.</li>
+<li><a href="./redirect-to?url=http://example.com/"><code>/redirect-to?url=foo</code></a> 302 Redirects to the <em>foo</em> URL.</li>
+<li><code>/proxy?url=foo</code>, <code>/proxy/foo</code> Forwards the request to <em>foo</em> and streams back its response.</li>
+<li><a href="./cookies" data-bare-link="true"><code>/cookies</code></a> Returns the caller's cookies.</li>
+<li><code>/cookies/set?name=value</code>, <code>/cookies/set/:name/:value</code> Sets cookies and redirects to <code>/cookies</code>.</li>
+<li><code>/cookies/delete?name</code> Deletes cookies and redirects to <code>/cookies</code>.</li>
+<li><a href="./basic-auth/user/passwd"><code>/basic-auth/:user/:passwd</code></a> Challenges HTTPBasic Auth.</li>
+<li><a href="./hidden-basic-auth/user/passwd"><code>/hidden-basic-auth/:user/:passwd</code></a> 404'd BasicAuth.</li>
+<li><a href="./bearer" data-bare-link="true"><code>/bearer</code></a> Challenges Bearer Auth.</li>
+<li><a href="./digest-auth/auth/user/passwd"><code>/digest-auth/:qop/:user/:passwd[/:algorithm]</code></a> Challenges HTTP Digest Auth.</li>
+<li><a href="./delay/3"><code>/delay/:n</code></a> Delays responding for <em>n</em>–10 seconds.</li>
+</ul>
+</body>
+</html>
+`
+
+// New returns the handler for the / index page.
+func New() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(page))
+	})
+}
@@ -0,0 +1,20 @@
+// Package ip echoes the caller's origin address.
+package ip
+
+import (
+	"net/http"
+
+	"github.com/gebi/httpdump/internal/httpreq"
+	"github.com/gebi/httpdump/internal/render"
+)
+
+// New returns the handler for /ip.
+func New(r render.JSON) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var o struct {
+			Origin string `json:"origin"`
+		}
+		o.Origin = httpreq.Origin(req)
+		r.Write(w, o, http.StatusOK)
+	})
+}
@@ -0,0 +1,167 @@
+// Package proxy implements an outbound passthrough endpoint that forwards a
+// request to an arbitrary upstream URL and streams the response back,
+// useful for exercising clients through a controllable HTTP middlebox.
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gebi/httpdump/internal/httpreq"
+)
+
+// Config controls which destinations the proxy endpoint will reach and how
+// long it will wait for a response.
+type Config struct {
+	// Allow lists host globs (e.g. "*.example.com") permitted as proxy
+	// destinations. An empty list permits any public host.
+	Allow []string
+	// Timeout bounds how long the upstream request may take.
+	Timeout time.Duration
+}
+
+func (c Config) hostAllowed(host string) bool {
+	if len(c.Allow) == 0 {
+		return true
+	}
+	for _, pattern := range c.Allow {
+		if ok, _ := path.Match(pattern, host); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// blockedAddr reports whether ip falls in a range that would let the
+// proxy be used to reach internal infrastructure (SSRF).
+func blockedAddr(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified()
+}
+
+var errDestinationNotPermitted = errors.New("destination not permitted")
+
+// checkURL validates a proxy destination before any network access:
+// scheme and the host allow-list. It does not resolve the host, since the
+// address that ends up dialed is validated separately in dialContext,
+// right before the connection it guards is made.
+func (c Config) checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("url parameter must be an absolute http(s) URL")
+	}
+	if !c.hostAllowed(u.Hostname()) {
+		return errDestinationNotPermitted
+	}
+	return nil
+}
+
+// dialContext resolves addr and connects to one of the resolved IPs
+// directly, rejecting any that fall in a blocked range. Validating and
+// dialing the same address this way (rather than validating a hostname and
+// letting the transport re-resolve it independently) closes a DNS-rebinding
+// window where a hostile name could answer the validation lookup with a
+// public IP and the real connection with a private one.
+func (c Config) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	var dialer net.Dialer
+	var lastErr error
+	for _, ip := range ips {
+		if blockedAddr(ip) {
+			lastErr = fmt.Errorf("%w: %s", errDestinationNotPermitted, ip)
+			continue
+		}
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no addresses found for %s", host)
+	}
+	return nil, lastErr
+}
+
+func targetURL(r *http.Request) string {
+	if u := r.URL.Query().Get("url"); u != "" {
+		return u
+	}
+	const prefix = "/proxy/"
+	if strings.HasPrefix(r.URL.Path, prefix) {
+		return strings.TrimPrefix(r.URL.Path, prefix)
+	}
+	return ""
+}
+
+// New returns the handler for /proxy and /proxy/*.
+func New(cfg Config) http.Handler {
+	client := &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: &http.Transport{DialContext: cfg.dialContext},
+		// Redirects are followed through the same Transport, so they dial
+		// through dialContext too, but CheckRedirect still needs to
+		// re-apply the host allow-list on each hop.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return cfg.checkURL(req.URL)
+		},
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dst, err := url.Parse(targetURL(r))
+		if err != nil || dst.Host == "" {
+			http.Error(w, "url parameter must be an absolute http(s) URL", http.StatusBadRequest)
+			return
+		}
+		if err := cfg.checkURL(dst); err != nil {
+			code := http.StatusBadRequest
+			if errors.Is(err, errDestinationNotPermitted) {
+				code = http.StatusForbidden
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, httpreq.MaxBytes)
+		outReq, err := http.NewRequest(r.Method, dst.String(), r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		outReq.Header = r.Header.Clone()
+
+		resp, err := client.Do(outReq)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+
+		for k, vv := range resp.Header {
+			for _, v := range vv {
+				w.Header().Add(k, v)
+			}
+		}
+		// The body below is capped at MaxBytes, so a larger declared
+		// Content-Length would promise bytes that are never sent.
+		w.Header().Del("Content-Length")
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, io.LimitReader(resp.Body, httpreq.MaxBytes))
+	})
+}
@@ -0,0 +1,58 @@
+package proxy
+
+import (
+	"net"
+	"testing"
+)
+
+func TestHostAllowed(t *testing.T) {
+	cases := []struct {
+		name  string
+		allow []string
+		host  string
+		want  bool
+	}{
+		{"empty allow-list permits anything", nil, "example.com", true},
+		{"exact match", []string{"example.com"}, "example.com", true},
+		{"glob match", []string{"*.example.com"}, "api.example.com", true},
+		{"glob does not match the bare domain", []string{"*.example.com"}, "example.com", false},
+		{"no match", []string{"example.com"}, "evil.com", false},
+		{"matches one of several patterns", []string{"foo.com", "*.example.com"}, "api.example.com", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := Config{Allow: c.allow}
+			if got := cfg.hostAllowed(c.host); got != c.want {
+				t.Errorf("hostAllowed(%q) with allow=%v = %v, want %v", c.host, c.allow, got, c.want)
+			}
+		})
+	}
+}
+
+func TestBlockedAddr(t *testing.T) {
+	cases := []struct {
+		ip   string
+		want bool
+	}{
+		{"127.0.0.1", true},
+		{"10.0.0.5", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true}, // link-local, e.g. cloud metadata endpoints
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"8.8.8.8", false},
+		{"93.184.216.34", false},
+	}
+	for _, c := range cases {
+		t.Run(c.ip, func(t *testing.T) {
+			ip := net.ParseIP(c.ip)
+			if ip == nil {
+				t.Fatalf("could not parse %q as an IP", c.ip)
+			}
+			if got := blockedAddr(ip); got != c.want {
+				t.Errorf("blockedAddr(%s) = %v, want %v", c.ip, got, c.want)
+			}
+		})
+	}
+}
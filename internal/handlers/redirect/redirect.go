@@ -0,0 +1,19 @@
+// Package redirect implements the /redirect-to endpoint.
+package redirect
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// New returns the handler for /redirect-to.
+func New() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dst := r.URL.Query().Get("url")
+		if _, err := url.Parse(dst); dst == "" || err != nil {
+			http.Error(w, "bad URL", http.StatusBadRequest)
+			return
+		}
+		http.Redirect(w, r, dst, http.StatusFound)
+	})
+}
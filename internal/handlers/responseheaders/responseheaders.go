@@ -0,0 +1,23 @@
+// Package responseheaders implements /response-headers, letting callers
+// script server-side header behaviour from query parameters.
+package responseheaders
+
+import (
+	"net/http"
+
+	"github.com/gebi/httpdump/internal/render"
+)
+
+// New returns the handler for /response-headers?Key=Value&.... Each query
+// pair is set as a response header, and the resulting header set is
+// returned as JSON.
+func New(r render.JSON) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		for key, values := range req.URL.Query() {
+			for _, v := range values {
+				w.Header().Add(key, v)
+			}
+		}
+		r.Write(w, w.Header(), http.StatusOK)
+	})
+}
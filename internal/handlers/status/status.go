@@ -0,0 +1,20 @@
+// Package status responds with an arbitrary HTTP status code.
+package status
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+)
+
+// New returns the handler for /status/:code.
+func New() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code, err := strconv.Atoi(path.Base(r.URL.Path))
+		if err != nil {
+			http.Error(w, "status code must be an integer", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(code)
+	})
+}
@@ -0,0 +1,45 @@
+// Package stream emits the request description (args, headers, origin and
+// URL) as a series of newline-delimited JSON documents.
+package stream
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/gebi/httpdump/internal/httpreq"
+	"github.com/gebi/httpdump/internal/render"
+)
+
+func min(a, b int) int {
+	if a <= b {
+		return a
+	}
+	return b
+}
+
+// New returns the handler for /stream/:n.
+func New(r render.JSON) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		n, err := strconv.Atoi(path.Base(req.URL.Path))
+		if err != nil || n < 0 {
+			http.Error(w, httpreq.ErrWantInteger, http.StatusBadRequest)
+			return
+		}
+		n = min(n, httpreq.MaxLines)
+		f, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, httpreq.ErrStreamingNotSupported, http.StatusBadRequest)
+			return
+		}
+		ret := httpreq.New(req)
+		r.Header(w)
+		for i := 0; i < n; i++ {
+			if err := json.NewEncoder(w).Encode(ret); err != nil {
+				return
+			}
+			f.Flush()
+		}
+	})
+}
@@ -0,0 +1,19 @@
+// Package useragent echoes the caller's User-Agent header.
+package useragent
+
+import (
+	"net/http"
+
+	"github.com/gebi/httpdump/internal/render"
+)
+
+// New returns the handler for /user-agent.
+func New(r render.JSON) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var resp struct {
+			UserAgent string `json:"user-agent"`
+		}
+		resp.UserAgent = req.Header.Get("User-Agent")
+		r.Write(w, resp, http.StatusOK)
+	})
+}
@@ -0,0 +1,143 @@
+// Package httpreq builds the JSON-able description of an inbound request
+// that most httpdump endpoints echo back, and binds request bodies onto it.
+package httpreq
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// MaxBytes caps the size of bodies httpdump will read or generate.
+	MaxBytes = 102400
+	// MaxLines caps the number of lines /stream/:n will emit.
+	MaxLines = 100
+	// Loopback is the local address used to detect proxied requests.
+	Loopback = "127.0.0.1"
+
+	ErrWantInteger           = "n must be an integer"
+	ErrStreamingNotSupported = "your client does not support streaming"
+)
+
+// Request is the common shape echoed back by the GET-like and body-parsing
+// endpoints.
+type Request struct {
+	Args url.Values `json:"args"`
+	// Gzipped mirrors httpbin's /gzip response shape: true once the
+	// response was actually gzip-encoded. Encoding reports the same thing
+	// for the whole gzip/deflate/brotli family.
+	Gzipped  bool                `json:"gzipped,omitempty"`
+	Encoding string              `json:"encoding,omitempty"`
+	Headers  http.Header         `json:"headers"`
+	Origin   string              `json:"origin"`
+	URL      string              `json:"url"`
+	Method   string              `json:"method,omitempty"`
+	Form     url.Values          `json:"form,omitempty"`
+	Files    map[string][]string `json:"files,omitempty"`
+	JSON     interface{}         `json:"json,omitempty"`
+	Data     string              `json:"data,omitempty"`
+	Error    string              `json:"error,omitempty"`
+}
+
+func rawURL(r *http.Request) string {
+	var scheme string
+	if r.TLS == nil {
+		scheme = "http"
+	} else {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.String()
+}
+
+// Origin returns the caller's address, preferring X-Forwarded-For when the
+// request arrived via a loopback proxy.
+func Origin(r *http.Request) string {
+	host, _, _ := net.SplitHostPort(r.RemoteAddr)
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" && forwarded != host {
+		if host == Loopback {
+			return forwarded
+		}
+		host = fmt.Sprintf("%s, %s", forwarded, host)
+	}
+	return host
+}
+
+// New builds a Request describing r.
+func New(r *http.Request) Request {
+	ret := Request{
+		Args:    r.URL.Query(),
+		Headers: r.Header,
+		Origin:  Origin(r),
+		URL:     rawURL(r),
+	}
+	ret.Headers.Add("Host", r.Host)
+	return ret
+}
+
+// ParseBody fills in the Form, Files, JSON or Data fields of req according
+// to r's Content-Type, binding it the way a generic HTTP body parser would.
+// It returns false if it has already written a response to w (for example a
+// 413 once the body exceeds MaxBytes) and the caller should stop.
+func (req *Request) ParseBody(w http.ResponseWriter, r *http.Request) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxBytes)
+
+	fail := func(err error) bool {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return false
+		}
+		req.Error = err.Error()
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		mediaType = r.Header.Get("Content-Type")
+	}
+
+	switch mediaType {
+	case "application/json":
+		if err := json.NewDecoder(r.Body).Decode(&req.JSON); err != nil && err != io.EOF {
+			return fail(err)
+		}
+	case "application/xml", "text/xml":
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fail(err)
+		}
+		req.Data = string(body)
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return fail(err)
+		}
+		req.Form = r.PostForm
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(MaxBytes); err != nil {
+			return fail(err)
+		}
+		req.Form = r.PostForm
+		if r.MultipartForm != nil {
+			req.Files = make(map[string][]string)
+			for name, files := range r.MultipartForm.File {
+				for _, fh := range files {
+					req.Files[name] = append(req.Files[name], fmt.Sprintf("%s (%d bytes)", fh.Filename, fh.Size))
+				}
+			}
+		}
+	default:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fail(err)
+		}
+		req.Data = base64.StdEncoding.EncodeToString(body)
+	}
+	return true
+}
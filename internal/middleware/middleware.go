@@ -0,0 +1,33 @@
+// Package middleware holds the cross-cutting http.Handler wrappers applied
+// to every httpdump request.
+package middleware
+
+import (
+	"log"
+	"net/http"
+)
+
+// Config controls the behaviour of the middleware chain.
+type Config struct {
+	Debug bool
+}
+
+// Default wraps h with request logging (when Debug is set) and permissive
+// CORS headers, answering OPTIONS preflight requests directly.
+func (c Config) Default(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.Debug {
+			log.Printf("%s %s", r.Method, r.RequestURI)
+		}
+		if o := r.Header.Get("Origin"); o != "" {
+			w.Header().Set("Access-Control-Allow-Origin", o)
+			w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
+			w.Header().Set("Access-Control-Allow-Headers",
+				"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+			if r.Method == "OPTIONS" {
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
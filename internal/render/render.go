@@ -0,0 +1,41 @@
+// Package render writes handler responses in the formats httpdump's
+// endpoints share, currently just JSON.
+package render
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// JSON writes JSON responses, optionally pretty-printed.
+type JSON struct {
+	Pretty bool
+}
+
+// Header sets the Content-type header for a JSON response.
+func (j JSON) Header(w http.ResponseWriter) {
+	w.Header().Set("Content-type", "application/json")
+}
+
+// Write encodes data as JSON to w with the given status code.
+func (j JSON) Write(w http.ResponseWriter, data interface{}, code int) error {
+	j.Header(w)
+	w.WriteHeader(code)
+	return j.Encode(w, data)
+}
+
+// Encode writes data as JSON to w without touching headers or the status
+// code, so callers can route the encoded bytes through something else
+// first (a compressor, for example).
+func (j JSON) Encode(w io.Writer, data interface{}) error {
+	if !j.Pretty {
+		return json.NewEncoder(w).Encode(data)
+	}
+	out, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}